@@ -1,6 +1,10 @@
 package curator
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/samuel/go-zookeeper/zk"
 )
 
@@ -28,17 +32,43 @@ func NewDefaultACLProvider() ACLProvider {
 	return &defaultACLProvider{zk.WorldACL(zk.PermAll)}
 }
 
+// aclAggregateError reports the per-path failures encountered while applying
+// or reading an ACL recursively across a subtree. A node missing from
+// Errors succeeded.
+type aclAggregateError struct {
+	Errors map[string]error
+}
+
+func (e *aclAggregateError) Error() string {
+	return fmt.Sprintf("acl operation failed on %d path(s): %v", len(e.Errors), e.Errors)
+}
+
+// joinZKPath appends child to the znode path parent, taking care not to
+// double up the separator when parent is the root.
+func joinZKPath(parent string, child string) string {
+	if strings.HasSuffix(parent, "/") {
+		return parent + child
+	}
+
+	return parent + "/" + child
+}
+
 type getACLBuilder struct {
 	client        *curatorFramework
 	backgrounding backgrounding
 	stat          *zk.Stat
+	ctx           context.Context
 }
 
 func (b *getACLBuilder) ForPath(givenPath string) ([]zk.ACL, error) {
 	adjustedPath := b.client.fixForNamespace(givenPath, false)
 
 	if b.backgrounding.inBackground {
-		go b.pathInBackground(adjustedPath, givenPath)
+		if b.ctx != nil {
+			go b.pathInBackgroundWithContext(b.ctx, adjustedPath, givenPath)
+		} else {
+			go b.pathInBackground(adjustedPath, givenPath)
+		}
 
 		return nil, nil
 	} else {
@@ -46,6 +76,96 @@ func (b *getACLBuilder) ForPath(givenPath string) ([]zk.ACL, error) {
 	}
 }
 
+// ForPathWithContext behaves like ForPath, but aborts and returns ctx.Err()
+// as soon as ctx is done instead of waiting out the full retry loop.
+func (b *getACLBuilder) ForPathWithContext(ctx context.Context, givenPath string) ([]zk.ACL, error) {
+	adjustedPath := b.client.fixForNamespace(givenPath, false)
+
+	return b.pathInForegroundWithContext(ctx, adjustedPath)
+}
+
+// Recursive walks the subtree rooted at givenPath and returns the ACL list
+// currently applied to every node in it, keyed by path. The subtree is
+// listed first: if listing any node's children fails, Recursive returns that
+// error and no ACLs. Once listed, a failure to read a single node's ACL is
+// recorded in the returned aclAggregateError rather than aborting the walk,
+// so the caller gets the ACLs of every node that could be read.
+func (b *getACLBuilder) Recursive(givenPath string) (map[string][]zk.ACL, error) {
+	adjustedPath := b.client.fixForNamespace(givenPath, false)
+
+	paths, err := b.collectPaths(adjustedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	zkClient := b.client.ZookeeperClient()
+	results := make(map[string][]zk.ACL, len(paths))
+	aggregate := &aclAggregateError{Errors: map[string]error{}}
+
+	for _, path := range paths {
+		result, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+			if conn, err := zkClient.Conn(); err != nil {
+				return nil, err
+			} else {
+				acls, _, err := conn.GetACL(path)
+
+				return acls, err
+			}
+		}))
+
+		if err != nil {
+			aggregate.Errors[b.client.unfixForNamespace(path)] = err
+
+			continue
+		}
+
+		acls, _ := result.([]zk.ACL)
+
+		results[b.client.unfixForNamespace(path)] = acls
+	}
+
+	if len(aggregate.Errors) > 0 {
+		return results, aggregate
+	}
+
+	return results, nil
+}
+
+// collectPaths returns path and every descendant of path, discovered by
+// listing children through the same retry loop used for the ACL calls
+// themselves.
+func (b *getACLBuilder) collectPaths(path string) ([]string, error) {
+	zkClient := b.client.ZookeeperClient()
+
+	result, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+		if conn, err := zkClient.Conn(); err != nil {
+			return nil, err
+		} else {
+			children, _, err := conn.Children(path)
+
+			return children, err
+		}
+	}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	children, _ := result.([]string)
+	paths := []string{path}
+
+	for _, child := range children {
+		childPaths, err := b.collectPaths(joinZKPath(path, child))
+		if err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, childPaths...)
+	}
+
+	return paths, nil
+}
+
 func (b *getACLBuilder) pathInBackground(path string, givenPath string) {
 	tracer := b.client.ZookeeperClient().startTracer("getACLBuilder.pathInBackground")
 
@@ -69,14 +189,44 @@ func (b *getACLBuilder) pathInBackground(path string, givenPath string) {
 
 		event.name = GetNodeFromPath(event.path)
 
-		b.backgrounding.callback(b.client, event)
+		invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
 	}
 }
 
-func (b *getACLBuilder) pathInForeground(path string) ([]zk.ACL, error) {
+func (b *getACLBuilder) pathInBackgroundWithContext(ctx context.Context, path string, givenPath string) {
+	tracer := b.client.ZookeeperClient().startTracer("getACLBuilder.pathInBackgroundWithContext")
+
+	defer tracer.Commit()
+
+	acls, err := b.pathInForegroundWithContext(ctx, path)
+
+	if b.backgrounding.callback != nil {
+		event := &curatorEvent{
+			eventType: GET_ACL,
+			err:       err,
+			path:      b.client.unfixForNamespace(path),
+			acls:      acls,
+			stat:      b.stat,
+			context:   b.backgrounding.context,
+		}
+
+		if err != nil {
+			event.path = givenPath
+		}
+
+		event.name = GetNodeFromPath(event.path)
+
+		invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+	}
+}
+
+// pathInForegroundWithContext mirrors pathInForeground, but retries through
+// callWithRetryContext so ctx.Done() aborts the attempt loop itself instead
+// of leaving it running unsupervised after ctx.Err() is returned.
+func (b *getACLBuilder) pathInForegroundWithContext(ctx context.Context, path string) ([]zk.ACL, error) {
 	zkClient := b.client.ZookeeperClient()
 
-	result, err := zkClient.newRetryLoop().CallWithRetry(func() (interface{}, error) {
+	result, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
 		if conn, err := zkClient.Conn(); err != nil {
 			return nil, err
 		} else {
@@ -95,6 +245,28 @@ func (b *getACLBuilder) pathInForeground(path string) ([]zk.ACL, error) {
 	return acls, err
 }
 
+func (b *getACLBuilder) pathInForeground(path string) ([]zk.ACL, error) {
+	zkClient := b.client.ZookeeperClient()
+
+	result, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+		if conn, err := zkClient.Conn(); err != nil {
+			return nil, err
+		} else {
+			acls, stat, err := conn.GetACL(path)
+
+			if stat != nil && b.stat != nil {
+				*b.stat = *stat
+			}
+
+			return acls, err
+		}
+	}))
+
+	acls, _ := result.([]zk.ACL)
+
+	return acls, err
+}
+
 func (b *getACLBuilder) StoringStatIn(stat *zk.Stat) GetACLBuilder {
 	b.stat = stat
 
@@ -125,30 +297,70 @@ func (b *getACLBuilder) InBackgroundWithCallbackAndContext(callback BackgroundCa
 	return b
 }
 
+// InBackgroundWithGoContext runs the operation in the background like
+// InBackgroundWithCallback, but threads ctx through the retry loop so the
+// operation aborts promptly on cancellation/deadline instead of leaking a
+// goroutine that retries against an unreachable znode. The resulting event
+// carries ctx.Err() when ctx ends before the operation does.
+func (b *getACLBuilder) InBackgroundWithGoContext(ctx context.Context, callback BackgroundCallback) GetACLBuilder {
+	b.ctx = ctx
+	b.backgrounding = backgrounding{inBackground: true, callback: callback}
+
+	return b
+}
+
 type setACLBuilder struct {
-	client        *curatorFramework
-	backgrounding backgrounding
-	acling        acling
-	version       int
+	client          *curatorFramework
+	backgrounding   backgrounding
+	acling          acling
+	version         int
+	recursive       bool
+	creatingParents bool
+	ctx             context.Context
 }
 
 func (b *setACLBuilder) ForPath(givenPath string) (*zk.Stat, error) {
 	adjustedPath := b.client.fixForNamespace(givenPath, false)
 
 	if b.backgrounding.inBackground {
-		go b.pathInBackground(adjustedPath, givenPath)
+		if b.ctx != nil {
+			go b.pathInBackgroundWithContext(b.ctx, adjustedPath, givenPath)
+		} else {
+			go b.pathInBackground(adjustedPath, givenPath)
+		}
 
 		return nil, nil
+	} else if b.recursive {
+		return nil, b.recursiveInForeground(adjustedPath)
 	} else {
 		return b.pathInForeground(adjustedPath)
 	}
 }
 
+// ForPathWithContext behaves like ForPath, but aborts and returns ctx.Err()
+// as soon as ctx is done instead of waiting out the full retry loop. Like
+// ForPath, it dispatches to the recursive walk when Recursive() was set.
+func (b *setACLBuilder) ForPathWithContext(ctx context.Context, givenPath string) (*zk.Stat, error) {
+	adjustedPath := b.client.fixForNamespace(givenPath, false)
+
+	if b.recursive {
+		return nil, b.recursiveInForegroundWithContext(ctx, adjustedPath)
+	}
+
+	return b.pathInForegroundWithContext(ctx, adjustedPath)
+}
+
 func (b *setACLBuilder) pathInBackground(path string, givenPath string) {
 	tracer := b.client.ZookeeperClient().startTracer("setACLBuilder.pathInBackground")
 
 	defer tracer.Commit()
 
+	if b.recursive {
+		b.recursiveInBackground(path, givenPath)
+
+		return
+	}
+
 	stat, err := b.pathInForeground(path)
 
 	if b.backgrounding.callback != nil {
@@ -156,7 +368,7 @@ func (b *setACLBuilder) pathInBackground(path string, givenPath string) {
 			eventType: SET_ACL,
 			err:       err,
 			path:      b.client.unfixForNamespace(path),
-			acls:      b.acling.aclList,
+			acls:      b.aclForPath(path),
 			stat:      stat,
 			context:   b.backgrounding.context,
 		}
@@ -167,18 +379,448 @@ func (b *setACLBuilder) pathInBackground(path string, givenPath string) {
 
 		event.name = GetNodeFromPath(event.path)
 
-		b.backgrounding.callback(b.client, event)
+		invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
 	}
 }
 
-func (b *setACLBuilder) pathInForeground(path string) (*zk.Stat, error) {
+// aclForPath resolves the ACL list to apply to path: the explicit list given
+// to WithACL if one was supplied, otherwise whatever the framework's
+// ACLProvider says applies to that specific path.
+func (b *setACLBuilder) aclForPath(path string) []zk.ACL {
+	if b.acling.aclList != nil {
+		return b.acling.aclList
+	}
+
+	if b.client.aclProvider != nil {
+		return b.client.aclProvider.GetAclForPath(path)
+	}
+
+	return nil
+}
+
+// collectPaths returns path and every descendant of path, discovered by
+// listing children through the same retry loop used for the ACL calls
+// themselves.
+func (b *setACLBuilder) collectPaths(path string) ([]string, error) {
 	zkClient := b.client.ZookeeperClient()
 
-	result, err := zkClient.newRetryLoop().CallWithRetry(func() (interface{}, error) {
+	result, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
 		if conn, err := zkClient.Conn(); err != nil {
 			return nil, err
 		} else {
-			return conn.SetACL(path, b.acling.aclList, int32(b.version))
+			children, _, err := conn.Children(path)
+
+			return children, err
+		}
+	}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	children, _ := result.([]string)
+	paths := []string{path}
+
+	for _, child := range children {
+		childPaths, err := b.collectPaths(joinZKPath(path, child))
+		if err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, childPaths...)
+	}
+
+	return paths, nil
+}
+
+// recursiveInForeground applies the builder's ACL to path and every node
+// beneath it, aggregating any per-node SetACL failures instead of stopping
+// at the first one. A failure to create missing parents or to list the
+// subtree is returned as is, before any ACL is set.
+func (b *setACLBuilder) recursiveInForeground(path string) error {
+	if b.creatingParents {
+		if err := MakeDirs(b.client.ZookeeperClient(), path, true, b.client.aclProvider); err != nil {
+			return err
+		}
+	}
+
+	paths, err := b.collectPaths(path)
+	if err != nil {
+		return err
+	}
+
+	zkClient := b.client.ZookeeperClient()
+	aggregate := &aclAggregateError{Errors: map[string]error{}}
+
+	for _, p := range paths {
+		_, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+			if conn, err := zkClient.Conn(); err != nil {
+				return nil, err
+			} else {
+				return conn.SetACL(p, b.aclForPath(p), int32(b.version))
+			}
+		}))
+
+		if err != nil {
+			aggregate.Errors[b.client.unfixForNamespace(p)] = err
+		}
+	}
+
+	if len(aggregate.Errors) > 0 {
+		return aggregate
+	}
+
+	return nil
+}
+
+// collectPathsWithContext mirrors collectPaths, but retries through
+// callWithRetryContext and aborts the walk as soon as ctx is done.
+func (b *setACLBuilder) collectPathsWithContext(ctx context.Context, path string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	zkClient := b.client.ZookeeperClient()
+
+	result, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
+		if conn, err := zkClient.Conn(); err != nil {
+			return nil, err
+		} else {
+			children, _, err := conn.Children(path)
+
+			return children, err
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	children, _ := result.([]string)
+	paths := []string{path}
+
+	for _, child := range children {
+		childPaths, err := b.collectPathsWithContext(ctx, joinZKPath(path, child))
+		if err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, childPaths...)
+	}
+
+	return paths, nil
+}
+
+// recursiveInForegroundWithContext mirrors recursiveInForeground, but
+// retries through callWithRetryContext and gives up on the remaining walk
+// as soon as ctx is cancelled or times out.
+func (b *setACLBuilder) recursiveInForegroundWithContext(ctx context.Context, path string) error {
+	zkClient := b.client.ZookeeperClient()
+
+	if b.creatingParents {
+		if _, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
+			return nil, MakeDirs(zkClient, path, true, b.client.aclProvider)
+		}); err != nil {
+			return err
+		}
+	}
+
+	paths, err := b.collectPathsWithContext(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	aggregate := &aclAggregateError{Errors: map[string]error{}}
+
+	for _, p := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		_, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
+			if conn, err := zkClient.Conn(); err != nil {
+				return nil, err
+			} else {
+				return conn.SetACL(p, b.aclForPath(p), int32(b.version))
+			}
+		})
+
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+
+			aggregate.Errors[b.client.unfixForNamespace(p)] = err
+		}
+	}
+
+	if len(aggregate.Errors) > 0 {
+		return aggregate
+	}
+
+	return nil
+}
+
+// recursiveInBackgroundWithContext mirrors recursiveInBackground, but
+// retries through callWithRetryContext and stops visiting further nodes as
+// soon as ctx is cancelled or times out, reporting ctx.Err() in the final
+// summary event.
+func (b *setACLBuilder) recursiveInBackgroundWithContext(ctx context.Context, path string, givenPath string) {
+	zkClient := b.client.ZookeeperClient()
+
+	if b.creatingParents {
+		if _, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
+			return nil, MakeDirs(zkClient, path, true, b.client.aclProvider)
+		}); err != nil {
+			if b.backgrounding.callback != nil {
+				event := &curatorEvent{
+					eventType: SET_ACL,
+					err:       err,
+					path:      givenPath,
+					context:   b.backgrounding.context,
+				}
+
+				event.name = GetNodeFromPath(event.path)
+
+				invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+			}
+
+			return
+		}
+	}
+
+	paths, err := b.collectPathsWithContext(ctx, path)
+	if err != nil {
+		if b.backgrounding.callback != nil {
+			event := &curatorEvent{
+				eventType: SET_ACL,
+				err:       err,
+				path:      givenPath,
+				context:   b.backgrounding.context,
+			}
+
+			event.name = GetNodeFromPath(event.path)
+
+			invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+		}
+
+		return
+	}
+
+	aggregate := &aclAggregateError{Errors: map[string]error{}}
+	aborted := false
+
+	for _, p := range paths {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			aggregate.Errors[b.client.unfixForNamespace(p)] = ctxErr
+			aborted = true
+
+			break
+		}
+
+		acls := b.aclForPath(p)
+
+		result, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
+			if conn, err := zkClient.Conn(); err != nil {
+				return nil, err
+			} else {
+				return conn.SetACL(p, acls, int32(b.version))
+			}
+		})
+
+		stat, _ := result.(*zk.Stat)
+
+		if err != nil {
+			aggregate.Errors[b.client.unfixForNamespace(p)] = err
+		}
+
+		if b.backgrounding.callback != nil {
+			event := &curatorEvent{
+				eventType: SET_ACL,
+				err:       err,
+				path:      b.client.unfixForNamespace(p),
+				acls:      acls,
+				stat:      stat,
+				context:   b.backgrounding.context,
+			}
+
+			event.name = GetNodeFromPath(event.path)
+
+			invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+		}
+	}
+
+	if b.backgrounding.callback != nil {
+		var summaryErr error
+
+		if aborted {
+			summaryErr = ctx.Err()
+		} else if len(aggregate.Errors) > 0 {
+			summaryErr = aggregate
+		}
+
+		event := &curatorEvent{
+			eventType: SET_ACL,
+			err:       summaryErr,
+			path:      b.client.unfixForNamespace(path),
+			context:   b.backgrounding.context,
+		}
+
+		event.name = GetNodeFromPath(event.path)
+
+		invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+	}
+}
+
+// recursiveInBackground mirrors recursiveInForeground but, when a callback
+// is registered, emits a SET_ACL event per visited node plus a final summary
+// SET_ACL event for the root path carrying the aggregated error, if any.
+func (b *setACLBuilder) recursiveInBackground(path string, givenPath string) {
+	if b.creatingParents {
+		if err := MakeDirs(b.client.ZookeeperClient(), path, true, b.client.aclProvider); err != nil {
+			if b.backgrounding.callback != nil {
+				event := &curatorEvent{
+					eventType: SET_ACL,
+					err:       err,
+					path:      givenPath,
+					context:   b.backgrounding.context,
+				}
+
+				event.name = GetNodeFromPath(event.path)
+
+				invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+			}
+
+			return
+		}
+	}
+
+	paths, err := b.collectPaths(path)
+	if err != nil {
+		if b.backgrounding.callback != nil {
+			event := &curatorEvent{
+				eventType: SET_ACL,
+				err:       err,
+				path:      givenPath,
+				context:   b.backgrounding.context,
+			}
+
+			event.name = GetNodeFromPath(event.path)
+
+			invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+		}
+
+		return
+	}
+
+	zkClient := b.client.ZookeeperClient()
+	aggregate := &aclAggregateError{Errors: map[string]error{}}
+
+	for _, p := range paths {
+		acls := b.aclForPath(p)
+
+		result, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+			if conn, err := zkClient.Conn(); err != nil {
+				return nil, err
+			} else {
+				return conn.SetACL(p, acls, int32(b.version))
+			}
+		}))
+
+		stat, _ := result.(*zk.Stat)
+
+		if err != nil {
+			aggregate.Errors[b.client.unfixForNamespace(p)] = err
+		}
+
+		if b.backgrounding.callback != nil {
+			event := &curatorEvent{
+				eventType: SET_ACL,
+				err:       err,
+				path:      b.client.unfixForNamespace(p),
+				acls:      acls,
+				stat:      stat,
+				context:   b.backgrounding.context,
+			}
+
+			event.name = GetNodeFromPath(event.path)
+
+			invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+		}
+	}
+
+	if b.backgrounding.callback != nil {
+		var summaryErr error
+
+		if len(aggregate.Errors) > 0 {
+			summaryErr = aggregate
+		}
+
+		event := &curatorEvent{
+			eventType: SET_ACL,
+			err:       summaryErr,
+			path:      b.client.unfixForNamespace(path),
+			context:   b.backgrounding.context,
+		}
+
+		event.name = GetNodeFromPath(event.path)
+
+		invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+	}
+}
+
+func (b *setACLBuilder) pathInBackgroundWithContext(ctx context.Context, path string, givenPath string) {
+	tracer := b.client.ZookeeperClient().startTracer("setACLBuilder.pathInBackgroundWithContext")
+
+	defer tracer.Commit()
+
+	if b.recursive {
+		b.recursiveInBackgroundWithContext(ctx, path, givenPath)
+
+		return
+	}
+
+	stat, err := b.pathInForegroundWithContext(ctx, path)
+
+	if b.backgrounding.callback != nil {
+		event := &curatorEvent{
+			eventType: SET_ACL,
+			err:       err,
+			path:      b.client.unfixForNamespace(path),
+			acls:      b.aclForPath(path),
+			stat:      stat,
+			context:   b.backgrounding.context,
+		}
+
+		if err != nil {
+			event.path = givenPath
+		}
+
+		event.name = GetNodeFromPath(event.path)
+
+		invokeBackgroundCallback(b.client, b.backgrounding.callback, event)
+	}
+}
+
+// pathInForegroundWithContext mirrors pathInForeground, but retries through
+// callWithRetryContext so ctx.Done() aborts the attempt loop itself instead
+// of leaving it running unsupervised after ctx.Err() is returned.
+func (b *setACLBuilder) pathInForegroundWithContext(ctx context.Context, path string) (*zk.Stat, error) {
+	zkClient := b.client.ZookeeperClient()
+
+	if b.creatingParents {
+		if _, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
+			return nil, MakeDirs(zkClient, path, true, b.client.aclProvider)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := callWithRetryContext(ctx, zkClient, func() (interface{}, error) {
+		if conn, err := zkClient.Conn(); err != nil {
+			return nil, err
+		} else {
+			return conn.SetACL(path, b.aclForPath(path), int32(b.version))
 		}
 	})
 
@@ -187,6 +829,28 @@ func (b *setACLBuilder) pathInForeground(path string) (*zk.Stat, error) {
 	return stat, err
 }
 
+func (b *setACLBuilder) pathInForeground(path string) (*zk.Stat, error) {
+	zkClient := b.client.ZookeeperClient()
+
+	if b.creatingParents {
+		if err := MakeDirs(zkClient, path, true, b.client.aclProvider); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+		if conn, err := zkClient.Conn(); err != nil {
+			return nil, err
+		} else {
+			return conn.SetACL(path, b.aclForPath(path), int32(b.version))
+		}
+	}))
+
+	stat, _ := result.(*zk.Stat)
+
+	return stat, err
+}
+
 func (b *setACLBuilder) WithACL(acls ...zk.ACL) SetACLBuilder {
 	b.acling = acling{aclList: acls, aclProvider: b.client.aclProvider}
 
@@ -199,6 +863,25 @@ func (b *setACLBuilder) WithVersion(version int) SetACLBuilder {
 	return b
 }
 
+// Recursive makes ForPath apply the builder's ACL to the given path and to
+// every node beneath it, instead of just the given path.
+func (b *setACLBuilder) Recursive() SetACLBuilder {
+	b.recursive = true
+
+	return b
+}
+
+// CreatingParentsIfNeeded makes ForPath auto-create any missing ancestor
+// znodes, and the target node itself if it doesn't exist yet, before
+// applying the ACL. Created nodes get their ACL from the framework's
+// ACLProvider rather than defaulting to zk.WorldACL(zk.PermAll), so
+// administrators can provision a new subtree and its ACL policy in one call.
+func (b *setACLBuilder) CreatingParentsIfNeeded() SetACLBuilder {
+	b.creatingParents = true
+
+	return b
+}
+
 func (b *setACLBuilder) InBackground() SetACLBuilder {
 	b.backgrounding = backgrounding{inBackground: true}
 
@@ -222,3 +905,15 @@ func (b *setACLBuilder) InBackgroundWithCallbackAndContext(callback BackgroundCa
 
 	return b
 }
+
+// InBackgroundWithGoContext runs the operation in the background like
+// InBackgroundWithCallback, but threads ctx through the retry loop so the
+// operation aborts promptly on cancellation/deadline instead of leaking a
+// goroutine that retries against an unreachable znode. The resulting event
+// carries ctx.Err() when ctx ends before the operation does.
+func (b *setACLBuilder) InBackgroundWithGoContext(ctx context.Context, callback BackgroundCallback) SetACLBuilder {
+	b.ctx = ctx
+	b.backgrounding = backgrounding{inBackground: true, callback: callback}
+
+	return b
+}
@@ -0,0 +1,33 @@
+package curator
+
+import "testing"
+
+func TestJoinZKPath(t *testing.T) {
+	cases := []struct {
+		parent string
+		child  string
+		want   string
+	}{
+		{"/", "foo", "/foo"},
+		{"/foo", "bar", "/foo/bar"},
+		{"/foo/", "bar", "/foo/bar"},
+	}
+
+	for _, c := range cases {
+		if got := joinZKPath(c.parent, c.child); got != c.want {
+			t.Errorf("joinZKPath(%q, %q) = %q, want %q", c.parent, c.child, got, c.want)
+		}
+	}
+}
+
+func TestAclAggregateErrorError(t *testing.T) {
+	err := &aclAggregateError{Errors: map[string]error{"/foo": errNodeExistsStub{}}}
+
+	if err.Error() == "" {
+		t.Fatal("expected a non-empty error message")
+	}
+}
+
+type errNodeExistsStub struct{}
+
+func (errNodeExistsStub) Error() string { return "node exists" }
@@ -0,0 +1,111 @@
+package curator
+
+import (
+	"sync"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// NewAuthACLProvider returns the ACL list granted to whatever auth credential
+// is in effect for the current session (scheme "auth"), mirroring
+// zk.AuthACL without requiring callers to import the zk package themselves.
+func NewAuthACLProvider(perms int32) []zk.ACL {
+	return zk.AuthACL(perms)
+}
+
+// AuthInfo is a (scheme, auth) pair added to a session via Conn().AddAuth.
+// curatorFramework.AddAuth records these so reapplyAuth can replay them
+// against a connection; nothing in this package calls reapplyAuth on a
+// reconnect automatically, since that requires hooking into the framework's
+// connection-state listener, which lives outside these files. Code that does
+// watch for reconnects can call reapplyAuth itself to restore the credentials
+// a fresh session otherwise drops.
+type AuthInfo struct {
+	Scheme string
+	Auth   []byte
+}
+
+var (
+	authInfoLock sync.Mutex
+	authInfo     = map[*curatorFramework][]AuthInfo{}
+)
+
+// recordAuthInfo appends info to f's registered credentials.
+func recordAuthInfo(f *curatorFramework, info AuthInfo) {
+	authInfoLock.Lock()
+	defer authInfoLock.Unlock()
+
+	authInfo[f] = append(authInfo[f], info)
+}
+
+// authInfoFor returns a copy of f's registered credentials.
+func authInfoFor(f *curatorFramework) []AuthInfo {
+	authInfoLock.Lock()
+	defer authInfoLock.Unlock()
+
+	return append([]AuthInfo(nil), authInfo[f]...)
+}
+
+// ClearAuthInfo discards f's registered credentials and drops the reference
+// AddAuth's registry was holding on f. Whatever owns a curatorFramework's
+// lifecycle (e.g. its Close) should call this once the framework is torn
+// down - otherwise the registry keeps every framework that ever called
+// AddAuth reachable, and so unreclaimed, for the rest of the process's life.
+func (f *curatorFramework) ClearAuthInfo() {
+	authInfoLock.Lock()
+	defer authInfoLock.Unlock()
+
+	delete(authInfo, f)
+}
+
+// AddAuth records (scheme, auth) and applies every credential registered for
+// f so far, this one included, to the current session. This is the Go-side
+// equivalent of CuratorFrameworkBuilder.AddAuth in the reference
+// implementation: call it once per credential. A credential recorded while
+// there is no session to apply it to is applied only by a later reapplyAuth
+// call.
+func (f *curatorFramework) AddAuth(scheme string, auth []byte) error {
+	recordAuthInfo(f, AuthInfo{Scheme: scheme, Auth: auth})
+
+	return f.reapplyAuth()
+}
+
+// reapplyAuth replays every AuthInfo registered for f via AddAuth against the
+// current connection. Call it after a reconnect to restore credentials a
+// fresh session doesn't carry forward from the one it replaced.
+func (f *curatorFramework) reapplyAuth() error {
+	zkClient := f.ZookeeperClient()
+
+	for _, info := range authInfoFor(f) {
+		info := info
+
+		_, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+			if conn, err := zkClient.Conn(); err != nil {
+				return nil, err
+			} else {
+				return nil, conn.AddAuth(info.Scheme, info.Auth)
+			}
+		}))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DigestACL returns the single ACL entry ZooKeeper's digest scheme grants
+// zk.PermAll to user, using the user:base64(sha1(user:password)) identity
+// the digest scheme expects.
+func DigestACL(user string, password string) []zk.ACL {
+	return zk.DigestACL(zk.PermAll, user, password)
+}
+
+// WithDigestAuth sets the builder's ACL to a single digest-scheme entry
+// granting zk.PermAll to user, using the same
+// user:base64(sha1(user:password)) identity ZooKeeper's digest scheme
+// expects.
+func (b *setACLBuilder) WithDigestAuth(user string, password string) SetACLBuilder {
+	return b.WithACL(DigestACL(user, password)...)
+}
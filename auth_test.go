@@ -0,0 +1,64 @@
+package curator
+
+import (
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+func TestDigestACL(t *testing.T) {
+	acls := DigestACL("user", "pass")
+
+	if len(acls) != 1 {
+		t.Fatalf("got %d ACL entries, want 1", len(acls))
+	}
+
+	if acls[0].Perms != zk.PermAll {
+		t.Errorf("got Perms=%v, want zk.PermAll", acls[0].Perms)
+	}
+
+	if acls[0].Scheme != "digest" {
+		t.Errorf("got Scheme=%q, want %q", acls[0].Scheme, "digest")
+	}
+}
+
+func TestAuthInfoRegistry(t *testing.T) {
+	f := &curatorFramework{}
+	defer f.ClearAuthInfo()
+
+	recordAuthInfo(f, AuthInfo{Scheme: "digest", Auth: []byte("a:1")})
+	recordAuthInfo(f, AuthInfo{Scheme: "digest", Auth: []byte("a:2")})
+
+	got := authInfoFor(f)
+	if len(got) != 2 {
+		t.Fatalf("got %d entries, want 2", len(got))
+	}
+
+	if string(got[0].Auth) != "a:1" || string(got[1].Auth) != "a:2" {
+		t.Errorf("got %+v, want auth entries in insertion order", got)
+	}
+}
+
+func TestClearAuthInfoRemovesEntries(t *testing.T) {
+	f := &curatorFramework{}
+
+	recordAuthInfo(f, AuthInfo{Scheme: "digest", Auth: []byte("a:1")})
+	f.ClearAuthInfo()
+
+	if got := authInfoFor(f); len(got) != 0 {
+		t.Fatalf("got %d entries after ClearAuthInfo, want 0", len(got))
+	}
+}
+
+func TestAuthInfoRegistryIsPerFramework(t *testing.T) {
+	f1 := &curatorFramework{}
+	f2 := &curatorFramework{}
+	defer f1.ClearAuthInfo()
+	defer f2.ClearAuthInfo()
+
+	recordAuthInfo(f1, AuthInfo{Scheme: "digest", Auth: []byte("f1")})
+
+	if got := authInfoFor(f2); len(got) != 0 {
+		t.Fatalf("got %d entries for an unrelated framework, want 0", len(got))
+	}
+}
@@ -0,0 +1,53 @@
+package curator
+
+import (
+	"context"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// ctxRetryBaseDelay and ctxRetryMaxDelay bound the backoff callWithRetryContext
+// uses between attempts.
+const (
+	ctxRetryBaseDelay = 50 * time.Millisecond
+	ctxRetryMaxDelay  = 2 * time.Second
+)
+
+// callWithRetryContext retries fn until it succeeds or ctx is done, checking
+// ctx.Done() before every attempt and between backoffs. Unlike handing the
+// whole operation to zkClient.newRetryLoop().CallWithRetry in a detached
+// goroutine, this keeps the retrying itself on a path the caller's ctx can
+// actually cut short, so a cancelled/timed-out caller can't leave a
+// goroutine retrying against an unreachable znode forever. zk.ErrAuthFailed
+// and zk.ErrNoAuth are returned immediately without retrying, since neither
+// resolves itself by trying the same call again.
+func callWithRetryContext(ctx context.Context, zkClient *CuratorZookeeperClient, fn func() (interface{}, error)) (interface{}, error) {
+	delay := ctxRetryBaseDelay
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := safeCall(fn)()
+		if err == nil {
+			return result, nil
+		}
+
+		if err == zk.ErrAuthFailed || err == zk.ErrNoAuth {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > ctxRetryMaxDelay {
+			delay = ctxRetryMaxDelay
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package curator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+func TestCallWithRetryContextSucceedsEventually(t *testing.T) {
+	attempts := 0
+
+	result, err := callWithRetryContext(context.Background(), nil, func() (interface{}, error) {
+		attempts++
+
+		if attempts < 3 {
+			return nil, errors.New("transient")
+		}
+
+		return "ok", nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "ok" {
+		t.Fatalf("got %v, want ok", result)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestCallWithRetryContextStopsOnAuthError(t *testing.T) {
+	attempts := 0
+
+	_, err := callWithRetryContext(context.Background(), nil, func() (interface{}, error) {
+		attempts++
+
+		return nil, zk.ErrAuthFailed
+	})
+
+	if err != zk.ErrAuthFailed {
+		t.Fatalf("got %v, want zk.ErrAuthFailed", err)
+	}
+
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt, got %d", attempts)
+	}
+}
+
+func TestCallWithRetryContextRespectsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := callWithRetryContext(ctx, nil, func() (interface{}, error) {
+		t.Fatal("fn should not be called once ctx is already done")
+
+		return nil, nil
+	})
+
+	if err != context.Canceled {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestCallWithRetryContextStopsOnDeadlineDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := callWithRetryContext(ctx, nil, func() (interface{}, error) {
+		return nil, errors.New("always fails")
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+}
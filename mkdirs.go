@@ -0,0 +1,61 @@
+package curator
+
+import (
+	"strings"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// MakeDirs creates every missing ancestor of path and, if makeLastNode is
+// true, path itself. Each created node's ACL comes from
+// aclProvider.GetAclForPath, falling back to aclProvider.GetDefaultAcl only
+// when the provider returns an empty slice, so that freshly provisioned
+// trees pick up the configured ACL policy instead of zk.WorldACL(zk.PermAll).
+// It is idempotent: zk.ErrNodeExists is swallowed so concurrent creators
+// racing for the same ancestor don't fail each other.
+func MakeDirs(zkClient *CuratorZookeeperClient, path string, makeLastNode bool, aclProvider ACLProvider) error {
+	if path == "" || path == "/" {
+		return nil
+	}
+
+	if parent := parentZKPath(path); parent != "/" {
+		if err := MakeDirs(zkClient, parent, true, aclProvider); err != nil {
+			return err
+		}
+	}
+
+	if !makeLastNode {
+		return nil
+	}
+
+	acls := aclProvider.GetAclForPath(path)
+	if len(acls) == 0 {
+		acls = aclProvider.GetDefaultAcl()
+	}
+
+	_, err := zkClient.newRetryLoop().CallWithRetry(safeCall(func() (interface{}, error) {
+		if conn, err := zkClient.Conn(); err != nil {
+			return nil, err
+		} else {
+			return conn.Create(path, []byte{}, 0, acls)
+		}
+	}))
+
+	if err != nil && err != zk.ErrNodeExists {
+		return err
+	}
+
+	return nil
+}
+
+// parentZKPath returns the znode path one level above path, or "/" if path
+// is already a top-level node.
+func parentZKPath(path string) string {
+	idx := strings.LastIndex(path, "/")
+
+	if idx <= 0 {
+		return "/"
+	}
+
+	return path[:idx]
+}
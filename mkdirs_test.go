@@ -0,0 +1,21 @@
+package curator
+
+import "testing"
+
+func TestParentZKPath(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/foo", "/"},
+		{"/foo/bar", "/foo"},
+		{"/foo/bar/baz", "/foo/bar"},
+		{"/", "/"},
+	}
+
+	for _, c := range cases {
+		if got := parentZKPath(c.path); got != c.want {
+			t.Errorf("parentZKPath(%q) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
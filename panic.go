@@ -0,0 +1,78 @@
+package curator
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// CallbackPanicError wraps a panic recovered from background work (a user
+// BackgroundCallback or a retry-loop closure) so it travels through the
+// normal error-reporting paths instead of crashing the process.
+type CallbackPanicError struct {
+	Recovered interface{}
+	Stack     []byte
+}
+
+func (e *CallbackPanicError) Error() string {
+	return fmt.Sprintf("recovered panic in background callback: %v", e.Recovered)
+}
+
+// UnhandledErrorListener observes errors recovered from background work
+// across the framework - not just the ACL builders - so every subsystem can
+// report into one place instead of letting a panic take down the process.
+type UnhandledErrorListener func(client *curatorFramework, err error)
+
+var (
+	unhandledErrorListenerLock sync.RWMutex
+	unhandledErrorListener     UnhandledErrorListener
+)
+
+// SetUnhandledErrorListener registers the framework-wide listener invoked
+// whenever invokeBackgroundCallback recovers a panic from a
+// BackgroundCallback. A panic inside a closure wrapped by safeCall is
+// returned to its caller as a CallbackPanicError and does not reach the
+// listener.
+func SetUnhandledErrorListener(listener UnhandledErrorListener) {
+	unhandledErrorListenerLock.Lock()
+	defer unhandledErrorListenerLock.Unlock()
+
+	unhandledErrorListener = listener
+}
+
+func reportUnhandledError(client *curatorFramework, err error) {
+	unhandledErrorListenerLock.RLock()
+	listener := unhandledErrorListener
+	unhandledErrorListenerLock.RUnlock()
+
+	if listener != nil {
+		listener(client, err)
+	}
+}
+
+// invokeBackgroundCallback runs callback against event, recovering any panic
+// into a CallbackPanicError reported through the unhandled error listener
+// rather than crashing the background goroutine's process.
+func invokeBackgroundCallback(client *curatorFramework, callback BackgroundCallback, event *curatorEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			reportUnhandledError(client, &CallbackPanicError{Recovered: r, Stack: debug.Stack()})
+		}
+	}()
+
+	callback(client, event)
+}
+
+// safeCall wraps fn so that a panic inside it is recovered and turned into a
+// CallbackPanicError return value instead of crashing the retry loop.
+func safeCall(fn func() (interface{}, error)) func() (interface{}, error) {
+	return func() (result interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &CallbackPanicError{Recovered: r, Stack: debug.Stack()}
+			}
+		}()
+
+		return fn()
+	}
+}
@@ -0,0 +1,75 @@
+package curator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSafeCallRecoversPanic(t *testing.T) {
+	wrapped := safeCall(func() (interface{}, error) {
+		panic("kaboom")
+	})
+
+	_, err := wrapped()
+
+	var panicErr *CallbackPanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("got %v (%T), want a *CallbackPanicError", err, err)
+	}
+
+	if panicErr.Recovered != "kaboom" {
+		t.Errorf("got Recovered=%v, want kaboom", panicErr.Recovered)
+	}
+}
+
+func TestSafeCallPassesThroughNormalResult(t *testing.T) {
+	wrapped := safeCall(func() (interface{}, error) {
+		return "ok", nil
+	})
+
+	result, err := wrapped()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "ok" {
+		t.Fatalf("got %v, want ok", result)
+	}
+}
+
+func TestInvokeBackgroundCallbackRecoversPanic(t *testing.T) {
+	var captured error
+
+	SetUnhandledErrorListener(func(client *curatorFramework, err error) {
+		captured = err
+	})
+	defer SetUnhandledErrorListener(nil)
+
+	client := &curatorFramework{}
+	event := &curatorEvent{}
+
+	invokeBackgroundCallback(client, func(c *curatorFramework, e *curatorEvent) {
+		panic("boom")
+	}, event)
+
+	var panicErr *CallbackPanicError
+	if !errors.As(captured, &panicErr) {
+		t.Fatalf("got %v (%T), want a *CallbackPanicError", captured, captured)
+	}
+
+	if panicErr.Recovered != "boom" {
+		t.Errorf("got Recovered=%v, want boom", panicErr.Recovered)
+	}
+}
+
+func TestInvokeBackgroundCallbackRunsNormally(t *testing.T) {
+	var ran bool
+
+	invokeBackgroundCallback(&curatorFramework{}, func(c *curatorFramework, e *curatorEvent) {
+		ran = true
+	}, &curatorEvent{})
+
+	if !ran {
+		t.Fatal("expected the callback to run")
+	}
+}
@@ -0,0 +1,170 @@
+package curator
+
+import (
+	"strings"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+// ACLMatchMode controls how an ACLRule's Pattern is compared against the
+// path being resolved by a rule-based ACLProvider.
+type ACLMatchMode int
+
+const (
+	// Exact requires the path to equal Pattern exactly.
+	Exact ACLMatchMode = iota
+
+	// Prefix matches any path that starts with Pattern; among several
+	// matching Prefix rules the longest Pattern wins.
+	Prefix
+
+	// Glob matches Pattern as a simple path glob: "*" stands in for a
+	// single path segment and a trailing "/**" stands in for the
+	// segment itself and everything beneath it. The first Glob rule
+	// added that matches wins.
+	Glob
+)
+
+// ACLRule binds an ACL list to a path pattern for use with
+// NewRuleBasedACLProvider.
+type ACLRule struct {
+	Pattern string
+	ACLs    []zk.ACL
+	Mode    ACLMatchMode
+}
+
+// ACLProviderBuilder incrementally assembles a rule-based ACLProvider. Rules
+// are evaluated in the precedence documented on ACLMatchMode: an Exact match
+// always wins, then the longest matching Prefix, then the first matching
+// Glob; GetDefaultAcl() is returned when nothing matches.
+type ACLProviderBuilder interface {
+	AddExact(pattern string, acls ...zk.ACL) ACLProviderBuilder
+	AddPrefix(pattern string, acls ...zk.ACL) ACLProviderBuilder
+	AddGlob(pattern string, acls ...zk.ACL) ACLProviderBuilder
+	WithDefaultAcl(acls ...zk.ACL) ACLProviderBuilder
+	Build() ACLProvider
+}
+
+type ruleBasedACLProvider struct {
+	rules      []ACLRule
+	defaultAcl []zk.ACL
+}
+
+func (p *ruleBasedACLProvider) GetDefaultAcl() []zk.ACL {
+	return p.defaultAcl
+}
+
+func (p *ruleBasedACLProvider) GetAclForPath(path string) []zk.ACL {
+	var bestExact, bestPrefix, bestGlob *ACLRule
+
+	for i := range p.rules {
+		rule := &p.rules[i]
+
+		switch rule.Mode {
+		case Exact:
+			if bestExact == nil && rule.Pattern == path {
+				bestExact = rule
+			}
+		case Prefix:
+			if pathHasSegmentPrefix(path, rule.Pattern) {
+				if bestPrefix == nil || len(rule.Pattern) > len(bestPrefix.Pattern) {
+					bestPrefix = rule
+				}
+			}
+		case Glob:
+			if bestGlob == nil && globMatch(rule.Pattern, path) {
+				bestGlob = rule
+			}
+		}
+	}
+
+	switch {
+	case bestExact != nil:
+		return bestExact.ACLs
+	case bestPrefix != nil:
+		return bestPrefix.ACLs
+	case bestGlob != nil:
+		return bestGlob.ACLs
+	default:
+		return p.GetDefaultAcl()
+	}
+}
+
+// pathHasSegmentPrefix reports whether pattern matches path or one of its
+// proper znode-path ancestors that ends on a "/" boundary, so a rule for
+// "/config" matches "/config/db" but not the unrelated "/config-staging".
+func pathHasSegmentPrefix(path string, pattern string) bool {
+	return path == pattern || strings.HasPrefix(path, strings.TrimSuffix(pattern, "/")+"/")
+}
+
+// globMatch reports whether path satisfies pattern, where "*" matches any
+// single path segment and a trailing "/**" matches the segment itself and
+// everything beneath it.
+func globMatch(pattern string, path string) bool {
+	if strings.HasSuffix(pattern, "/**") {
+		prefixParts := strings.Split(strings.TrimSuffix(pattern, "/**"), "/")
+		pathParts := strings.Split(path, "/")
+
+		if len(pathParts) < len(prefixParts) {
+			return false
+		}
+
+		return globMatch(strings.Join(prefixParts, "/"), strings.Join(pathParts[:len(prefixParts)], "/"))
+	}
+
+	patternParts := strings.Split(pattern, "/")
+	pathParts := strings.Split(path, "/")
+
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+
+	for i, part := range patternParts {
+		if part != "*" && part != pathParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ruleBasedACLProviderBuilder struct {
+	provider *ruleBasedACLProvider
+}
+
+// NewRuleBasedACLProvider starts a builder for an ACLProvider that resolves
+// ACLs by matching the path against an ordered list of rules, falling back
+// to a default of zk.WorldACL(zk.PermAll) when nothing matches.
+func NewRuleBasedACLProvider() ACLProviderBuilder {
+	return &ruleBasedACLProviderBuilder{
+		provider: &ruleBasedACLProvider{defaultAcl: zk.WorldACL(zk.PermAll)},
+	}
+}
+
+func (b *ruleBasedACLProviderBuilder) AddExact(pattern string, acls ...zk.ACL) ACLProviderBuilder {
+	b.provider.rules = append(b.provider.rules, ACLRule{Pattern: pattern, ACLs: acls, Mode: Exact})
+
+	return b
+}
+
+func (b *ruleBasedACLProviderBuilder) AddPrefix(pattern string, acls ...zk.ACL) ACLProviderBuilder {
+	b.provider.rules = append(b.provider.rules, ACLRule{Pattern: pattern, ACLs: acls, Mode: Prefix})
+
+	return b
+}
+
+func (b *ruleBasedACLProviderBuilder) AddGlob(pattern string, acls ...zk.ACL) ACLProviderBuilder {
+	b.provider.rules = append(b.provider.rules, ACLRule{Pattern: pattern, ACLs: acls, Mode: Glob})
+
+	return b
+}
+
+func (b *ruleBasedACLProviderBuilder) WithDefaultAcl(acls ...zk.ACL) ACLProviderBuilder {
+	b.provider.defaultAcl = acls
+
+	return b
+}
+
+func (b *ruleBasedACLProviderBuilder) Build() ACLProvider {
+	return b.provider
+}
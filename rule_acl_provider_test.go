@@ -0,0 +1,95 @@
+package curator
+
+import (
+	"testing"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+func TestRuleBasedACLProviderPrecedence(t *testing.T) {
+	readOnly := zk.WorldACL(zk.PermRead)
+	secretsOnly := zk.DigestACL(zk.PermAll, "admin", "pw")
+	exact := zk.DigestACL(zk.PermAll, "root", "pw")
+
+	provider := NewRuleBasedACLProvider().
+		AddPrefix("/config", readOnly...).
+		AddPrefix("/config/secrets", secretsOnly...).
+		AddExact("/config/secrets/root", exact...).
+		Build()
+
+	cases := []struct {
+		path string
+		want []zk.ACL
+	}{
+		{"/config/db", readOnly},
+		{"/config/secrets/api-key", secretsOnly},
+		{"/config/secrets/root", exact},
+		{"/config-staging/db", nil},
+		{"/other", nil},
+	}
+
+	for _, c := range cases {
+		got := provider.GetAclForPath(c.path)
+
+		if c.want == nil {
+			if len(got) != len(provider.GetDefaultAcl()) {
+				t.Errorf("GetAclForPath(%q) = %v, want the default ACL", c.path, got)
+			}
+
+			continue
+		}
+
+		if !aclsEqual(got, c.want) {
+			t.Errorf("GetAclForPath(%q) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestRuleBasedACLProviderGlob(t *testing.T) {
+	singleLevel := zk.WorldACL(zk.PermRead)
+	subtree := zk.WorldACL(zk.PermAll)
+
+	provider := NewRuleBasedACLProvider().
+		AddGlob("/apps/*/config", singleLevel...).
+		AddGlob("/apps/shared/**", subtree...).
+		Build()
+
+	cases := []struct {
+		path string
+		want []zk.ACL
+	}{
+		{"/apps/billing/config", singleLevel},
+		{"/apps/shared/anything/deep", subtree},
+		{"/apps/billing/config/nested", nil},
+	}
+
+	for _, c := range cases {
+		got := provider.GetAclForPath(c.path)
+
+		if c.want == nil {
+			if len(got) != len(provider.GetDefaultAcl()) {
+				t.Errorf("GetAclForPath(%q) = %v, want the default ACL", c.path, got)
+			}
+
+			continue
+		}
+
+		if !aclsEqual(got, c.want) {
+			t.Errorf("GetAclForPath(%q) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func aclsEqual(a []zk.ACL, b []zk.ACL) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}